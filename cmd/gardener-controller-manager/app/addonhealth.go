@@ -0,0 +1,35 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gardener/gardener/pkg/operation/hybridbotanist"
+	shootpkg "github.com/gardener/gardener/pkg/operation/shoot"
+)
+
+// addonHealthInterval is how often the addon-health controller reconciles shoot.status.addonConditions.
+const addonHealthInterval = 30 * time.Second
+
+// AddAddonHealth starts hybridbotanist.RunAddonHealthController as a background goroutine scoped to ctx and
+// registers its "/healthz/addons/<name>" handler on mux. It is called once from Run, alongside
+// gardener-controller-manager's other controllers and HTTP routes.
+func AddAddonHealth(ctx context.Context, mux *http.ServeMux, shoot *shootpkg.Shoot, botanists func() []*hybridbotanist.HybridBotanist) {
+	go hybridbotanist.RunAddonHealthController(ctx, addonHealthInterval, botanists)
+	mux.Handle("/healthz/addons/", &hybridbotanist.AddonHealthHandler{Shoot: shoot})
+}
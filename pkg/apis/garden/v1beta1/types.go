@@ -0,0 +1,129 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultDomain is the domain under which a shoot's in-cluster services are resolvable. The kube-dns addon
+// always uses it; no field of the Shoot overrides it.
+const DefaultDomain = "cluster.local"
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Shoot represents a Kubernetes cluster created and managed by Gardener.
+type Shoot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ShootSpec   `json:"spec,omitempty"`
+	Status            ShootStatus `json:"status,omitempty"`
+}
+
+// ShootSpec is the specification of a Shoot.
+type ShootSpec struct {
+	// Addons contains information about enabled/disabled addons and their configuration.
+	Addons *Addons `json:"addons,omitempty"`
+	// Kubernetes contains the version and configuration settings of the control plane components.
+	Kubernetes Kubernetes `json:"kubernetes"`
+	// ImageRepository, if set, replaces the registry host of every addon image with this one, e.g. to mirror
+	// images through an internal registry. It must be a bare registry hostname (optionally with a port), not
+	// a full image reference.
+	ImageRepository string `json:"imageRepository,omitempty"`
+	// ImageRepositoryOverrides, if set, replaces the registry host of the named addon image with the given
+	// one, taking precedence over ImageRepository for that image.
+	ImageRepositoryOverrides map[string]string `json:"imageRepositoryOverrides,omitempty"`
+}
+
+// ShootStatus is the status of a Shoot.
+type ShootStatus struct {
+	// AddonConditions represents the conditions of the different addons of a shoot cluster.
+	AddonConditions []AddonCondition `json:"addonConditions,omitempty"`
+}
+
+// Kubernetes contains the version and configuration variables for the Shoot control plane.
+type Kubernetes struct {
+	// KubeSystemIsolation enables a default-deny NetworkPolicy in kube-system, opened up per-addon by the
+	// NetworkPolicy each addon contributes.
+	KubeSystemIsolation *bool `json:"kubeSystemIsolation,omitempty"`
+	// KubeProxy contains configuration settings for the kube-proxy addon.
+	KubeProxy *KubeProxyConfig `json:"kubeProxy,omitempty"`
+}
+
+// KubeProxyConfig contains configuration settings for the kube-proxy addon.
+type KubeProxyConfig struct {
+	// FeatureGates is a map of feature names to bools that enable or disable alpha/experimental features.
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+}
+
+// Addons is a collection of configuration for a Shoot cluster's addons.
+type Addons struct {
+	// NginxIngress holds configuration settings for the nginx-ingress addon.
+	NginxIngress *NginxIngress `json:"nginxIngress,omitempty"`
+	// ImageCache holds configuration settings for the pull-through image-cache addon.
+	ImageCache *ImageCache `json:"imageCache,omitempty"`
+}
+
+// NginxIngress describes configuration values for the nginx-ingress addon.
+type NginxIngress struct {
+	// LoadBalancerSourceRanges is list of allowed IP sources for the load balancer in front of nginx-ingress.
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+}
+
+// ImageCache describes configuration values for the pull-through image-cache addon.
+type ImageCache struct {
+	// Enabled activates the image-cache addon, a node-local registry mirror that every node's kubelet pulls
+	// images through.
+	Enabled bool `json:"enabled"`
+	// CacheSizeGiB is the amount of node-local disk, in gibibytes, reserved for the image cache.
+	CacheSizeGiB int64 `json:"cacheSizeGiB,omitempty"`
+	// UpstreamCredentialsSecretRef, if set, names the Secret in the shoot's seed namespace holding the
+	// credentials the image cache authenticates to upstream registries with.
+	UpstreamCredentialsSecretRef *corev1.LocalObjectReference `json:"upstreamCredentialsSecretRef,omitempty"`
+}
+
+// AddonPhase is a label for the condition of an addon at the current time.
+type AddonPhase string
+
+const (
+	// AddonPhaseReady indicates that all of an addon's resources are available.
+	AddonPhaseReady AddonPhase = "Ready"
+	// AddonPhaseNotReady indicates that at least one of an addon's resources is not yet available.
+	AddonPhaseNotReady AddonPhase = "NotReady"
+	// AddonPhaseUnknown indicates that an addon's resources could not be checked.
+	AddonPhaseUnknown AddonPhase = "Unknown"
+)
+
+// AddonCondition contains the current condition of a single shoot addon, as last computed by
+// gardener-controller-manager's addon-health controller.
+type AddonCondition struct {
+	// Name is the addon's chart values key, e.g. "kube-dns".
+	Name string `json:"name"`
+	// Phase is the addon's current condition.
+	Phase AddonPhase `json:"phase"`
+	// Message gives details about the addon's current phase, if it is not AddonPhaseReady.
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the last time the phase transitioned from one value to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// ShootList is a list of Shoot objects.
+type ShootList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Shoot `json:"items"`
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package validation validates Shoot API objects. It is called both by the validating admission webhook
+// and by the apiserver's REST storage strategy, so that a Shoot cannot be persisted with an invalid spec
+// regardless of which code path created or updated it.
+package validation
+
+import (
+	"fmt"
+	"regexp"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// registryHostnamePattern matches a bare registry hostname, optionally with a port, as accepted for
+// spec.imageRepository and spec.imageRepositoryOverrides (e.g. "registry.example.com",
+// "registry.example.com:5000", "localhost:5000"). It intentionally rejects anything containing a scheme
+// or a repository path, which belong after the hostname, not as part of it.
+var registryHostnamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*(:[0-9]{1,5})?$`)
+
+// ValidateImageRepositoryHostname reports whether repository is a valid registry hostname (with an
+// optional port), as required by spec.imageRepository and spec.imageRepositoryOverrides.
+func ValidateImageRepositoryHostname(repository string) error {
+	if !registryHostnamePattern.MatchString(repository) {
+		return fmt.Errorf("%q is not a valid registry hostname", repository)
+	}
+	return nil
+}
+
+// ValidateShootSpec validates a ShootSpec.
+func ValidateShootSpec(spec *gardenv1beta1.ShootSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.ImageRepository != "" {
+		if err := ValidateImageRepositoryHostname(spec.ImageRepository); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("imageRepository"), spec.ImageRepository, err.Error()))
+		}
+	}
+	for name, override := range spec.ImageRepositoryOverrides {
+		if err := ValidateImageRepositoryHostname(override); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("imageRepositoryOverrides").Key(name), override, err.Error()))
+		}
+	}
+
+	return allErrs
+}
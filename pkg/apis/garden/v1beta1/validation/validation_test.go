@@ -0,0 +1,70 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"testing"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateImageRepositoryHostname(t *testing.T) {
+	valid := []string{"registry.example.com", "registry.example.com:5000", "localhost:5000", "harbor"}
+	invalid := []string{"", "http://registry.example.com", "registry.example.com/mirror", "registry.example.com/../x"}
+
+	for _, hostname := range valid {
+		if err := ValidateImageRepositoryHostname(hostname); err != nil {
+			t.Errorf("expected %q to be a valid registry hostname, got error: %v", hostname, err)
+		}
+	}
+	for _, hostname := range invalid {
+		if err := ValidateImageRepositoryHostname(hostname); err == nil {
+			t.Errorf("expected %q to be rejected as an invalid registry hostname", hostname)
+		}
+	}
+}
+
+func TestValidateShootSpecRejectsInvalidImageRepository(t *testing.T) {
+	spec := &gardenv1beta1.ShootSpec{ImageRepository: "registry.example.com/mirror"}
+
+	errs := ValidateShootSpec(spec, field.NewPath("spec"))
+	if len(errs) != 1 {
+		t.Fatalf("expected exactly one error for an invalid imageRepository, got %v", errs)
+	}
+	if errs[0].Field != "spec.imageRepository" {
+		t.Errorf("expected the error to be reported against spec.imageRepository, got %q", errs[0].Field)
+	}
+}
+
+func TestValidateShootSpecRejectsInvalidImageRepositoryOverride(t *testing.T) {
+	spec := &gardenv1beta1.ShootSpec{
+		ImageRepositoryOverrides: map[string]string{"calico-node": "not a hostname"},
+	}
+
+	errs := ValidateShootSpec(spec, field.NewPath("spec"))
+	if len(errs) != 1 {
+		t.Fatalf("expected exactly one error for an invalid override, got %v", errs)
+	}
+	if errs[0].Field != "spec.imageRepositoryOverrides[calico-node]" {
+		t.Errorf("expected the error to be reported against the specific override key, got %q", errs[0].Field)
+	}
+}
+
+func TestValidateShootSpecAcceptsEmptySpec(t *testing.T) {
+	if errs := ValidateShootSpec(&gardenv1beta1.ShootSpec{}, field.NewPath("spec")); len(errs) != 0 {
+		t.Errorf("expected no errors for a shoot spec without an image repository, got %v", errs)
+	}
+}
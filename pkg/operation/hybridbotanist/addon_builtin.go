@@ -0,0 +1,354 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybridbotanist
+
+import (
+	"fmt"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	"github.com/gardener/gardener/pkg/apis/garden/v1beta1/helper"
+	"github.com/gardener/gardener/pkg/operation/common"
+	shootpkg "github.com/gardener/gardener/pkg/operation/shoot"
+	"github.com/gardener/gardener/pkg/utils"
+	"github.com/gardener/gardener/pkg/utils/secrets"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	Register(&calicoAddon{})
+	Register(&kubeDNSAddon{})
+	Register(&kubeProxyAddon{})
+	Register(&metricsServerAddon{})
+	Register(&vpnShootAddon{})
+	Register(&nodeExporterAddon{})
+
+	Register(&clusterAutoscalerAddon{})
+	Register(&helmTillerAddon{})
+	Register(&kubeLegoAddon{})
+	Register(&kube2IAMAddon{})
+	Register(&kubernetesDashboardAddon{})
+	Register(&monocularAddon{})
+	Register(&nginxIngressAddon{})
+}
+
+type calicoAddon struct{}
+
+func (*calicoAddon) Name() string                { return "calico" }
+func (*calicoAddon) Category() AddonCategory     { return AddonCategoryCore }
+func (*calicoAddon) Enabled(*shootpkg.Shoot) bool { return true }
+func (*calicoAddon) Images() map[string]string {
+	return map[string]string{"calico-node": "calico-node", "calico-cni": "calico-cni", "calico-typha": "calico-typha"}
+}
+func (*calicoAddon) GenerateConfig(b *HybridBotanist) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"cloudProvider": b.Shoot.CloudProvider,
+	}, nil
+}
+
+type kubeDNSAddon struct{}
+
+func (*kubeDNSAddon) Name() string                { return "kube-dns" }
+func (*kubeDNSAddon) Category() AddonCategory     { return AddonCategoryCore }
+func (*kubeDNSAddon) Enabled(*shootpkg.Shoot) bool { return true }
+func (*kubeDNSAddon) Images() map[string]string {
+	return map[string]string{
+		"kube-dns":            "kube-dns",
+		"kube-dns-dnsmasq":    "kube-dns-dnsmasq",
+		"kube-dns-sidecar":    "kube-dns-sidecar",
+		"kube-dns-autoscaler": "cluster-proportional-autoscaler",
+	}
+}
+func (*kubeDNSAddon) GenerateConfig(b *HybridBotanist) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"clusterDNS": common.ComputeClusterIP(b.Shoot.GetServiceNetwork(), 10),
+		// TODO: resolve conformance test issue before changing:
+		// https://github.com/kubernetes/kubernetes/blob/master/test/e2e/network/dns.go#L44
+		"domain": gardenv1beta1.DefaultDomain,
+	}, nil
+}
+func (*kubeDNSAddon) NetworkPolicy(b *HybridBotanist) (AddonNetworkPolicy, error) {
+	return AddonNetworkPolicy{
+		Name: "kube-dns",
+		Ingress: []AddonNetworkPolicyRule{
+			{
+				Ports: []AddonNetworkPolicyPort{
+					{Protocol: "UDP", Port: 53},
+					{Protocol: "TCP", Port: 53},
+				},
+				Peers: []AddonNetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}},
+			},
+		},
+		Egress: []AddonNetworkPolicyRule{
+			{
+				Ports: []AddonNetworkPolicyPort{{Protocol: "TCP", Port: 443}},
+				Peers: []AddonNetworkPolicyPeer{singleHostPeer(common.ComputeClusterIP(b.Shoot.GetServiceNetwork(), 1))},
+			},
+		},
+	}, nil
+}
+
+type kubeProxyAddon struct{}
+
+func (*kubeProxyAddon) Name() string                { return "kube-proxy" }
+func (*kubeProxyAddon) Category() AddonCategory     { return AddonCategoryCore }
+func (*kubeProxyAddon) Enabled(*shootpkg.Shoot) bool { return true }
+func (*kubeProxyAddon) Images() map[string]string   { return map[string]string{"hyperkube": "hyperkube"} }
+func (*kubeProxyAddon) GenerateConfig(b *HybridBotanist) (map[string]interface{}, error) {
+	config := map[string]interface{}{
+		"kubeconfig": b.Secrets["kube-proxy"].Data["kubeconfig"],
+		"podAnnotations": map[string]interface{}{
+			"checksum/secret-kube-proxy": b.CheckSums["kube-proxy"],
+		},
+	}
+
+	if proxyConfig := b.Shoot.Info.Spec.Kubernetes.KubeProxy; proxyConfig != nil {
+		config["featureGates"] = proxyConfig.FeatureGates
+	}
+
+	return config, nil
+}
+
+type metricsServerAddon struct{}
+
+func (*metricsServerAddon) Name() string                { return "metrics-server" }
+func (*metricsServerAddon) Category() AddonCategory     { return AddonCategoryCore }
+func (*metricsServerAddon) Enabled(*shootpkg.Shoot) bool { return true }
+func (*metricsServerAddon) Images() map[string]string {
+	return map[string]string{"metrics-server": "metrics-server"}
+}
+func (*metricsServerAddon) GenerateConfig(b *HybridBotanist) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"tls": map[string]interface{}{
+			"caBundle": b.Secrets["ca-metrics-server"].Data[secrets.DataKeyCertificateCA],
+		},
+		"secret": map[string]interface{}{
+			"data": b.Secrets["metrics-server"].Data,
+		},
+	}, nil
+}
+func (*metricsServerAddon) NetworkPolicy(b *HybridBotanist) (AddonNetworkPolicy, error) {
+	return AddonNetworkPolicy{
+		Name: "metrics-server",
+		Ingress: []AddonNetworkPolicyRule{
+			{
+				// Only the apiserver talks to metrics-server, not every address in the service network.
+				Ports: []AddonNetworkPolicyPort{{Protocol: "TCP", Port: 443}},
+				Peers: []AddonNetworkPolicyPeer{singleHostPeer(common.ComputeClusterIP(b.Shoot.GetServiceNetwork(), 1))},
+			},
+		},
+		Egress: []AddonNetworkPolicyRule{
+			{
+				Ports: []AddonNetworkPolicyPort{{Protocol: "TCP", Port: 10250}},
+				Peers: []AddonNetworkPolicyPeer{{CIDR: string(b.Shoot.GetNodeNetwork())}},
+			},
+		},
+	}, nil
+}
+
+type vpnShootAddon struct{}
+
+func (*vpnShootAddon) Name() string                { return "vpn-shoot" }
+func (*vpnShootAddon) Category() AddonCategory     { return AddonCategoryCore }
+func (*vpnShootAddon) Enabled(*shootpkg.Shoot) bool { return true }
+func (*vpnShootAddon) Images() map[string]string   { return map[string]string{"vpn-shoot": "vpn-shoot"} }
+func (*vpnShootAddon) GenerateConfig(b *HybridBotanist) (map[string]interface{}, error) {
+	vpnShootSecret := b.Secrets["vpn-shoot"]
+
+	config := map[string]interface{}{
+		"podNetwork":     b.Shoot.GetPodNetwork(),
+		"serviceNetwork": b.Shoot.GetServiceNetwork(),
+		"nodeNetwork":    b.Shoot.GetNodeNetwork(),
+		"tlsAuth":        b.Secrets["vpn-seed-tlsauth"].Data["vpn.tlsauth"],
+		"podAnnotations": map[string]interface{}{
+			"checksum/secret-vpn-shoot": b.CheckSums["vpn-shoot"],
+		},
+	}
+
+	if openvpnDiffieHellmanSecret, ok := b.Secrets[common.GardenRoleOpenVPNDiffieHellman]; ok {
+		config["diffieHellmanKey"] = openvpnDiffieHellmanSecret.Data["dh2048.pem"]
+	}
+
+	if _, err := b.K8sShootClient.CreateSecret(metav1.NamespaceSystem, "vpn-shoot", corev1.SecretTypeOpaque, vpnShootSecret.Data, true); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+func (*vpnShootAddon) NetworkPolicy(b *HybridBotanist) (AddonNetworkPolicy, error) {
+	endpoint, err := seedVPNEndpoint(b)
+	if err != nil {
+		return AddonNetworkPolicy{}, fmt.Errorf("failed to determine seed VPN endpoint: %v", err)
+	}
+
+	return AddonNetworkPolicy{
+		Name: "vpn-shoot",
+		Egress: []AddonNetworkPolicyRule{
+			{
+				// OpenVPN port of the seed's vpn-seed endpoint.
+				Ports: []AddonNetworkPolicyPort{{Protocol: "TCP", Port: 4314}},
+				Peers: []AddonNetworkPolicyPeer{singleHostPeer(endpoint)},
+			},
+		},
+	}, nil
+}
+
+// seedVPNEndpoint resolves the address of the seed's vpn-seed Service that vpn-shoot dials out to,
+// preferring its LoadBalancer ingress address and falling back to its cluster IP for seed-internal setups.
+func seedVPNEndpoint(b *HybridBotanist) (string, error) {
+	vpnSeedService, err := b.K8sSeedClient.GetService(b.Shoot.SeedNamespace, "vpn-seed")
+	if err != nil {
+		return "", err
+	}
+
+	if ingress := vpnSeedService.Status.LoadBalancer.Ingress; len(ingress) > 0 && ingress[0].IP != "" {
+		return ingress[0].IP, nil
+	}
+
+	return vpnSeedService.Spec.ClusterIP, nil
+}
+
+type nodeExporterAddon struct{}
+
+func (*nodeExporterAddon) Name() string                { return "node-exporter" }
+func (*nodeExporterAddon) Category() AddonCategory     { return AddonCategoryCore }
+func (*nodeExporterAddon) Enabled(*shootpkg.Shoot) bool { return true }
+func (*nodeExporterAddon) Images() map[string]string {
+	return map[string]string{"node-exporter": "node-exporter"}
+}
+
+// GenerateConfig returns no values of its own; node-exporter's chart values are the image reference
+// injected by generateAddonsConfig. generateCoreAddonsChart nests the result under "monitoring.node-exporter"
+// to match the shoot-core chart's expected shape.
+func (*nodeExporterAddon) GenerateConfig(b *HybridBotanist) (map[string]interface{}, error) {
+	return map[string]interface{}{}, nil
+}
+func (*nodeExporterAddon) NetworkPolicy(b *HybridBotanist) (AddonNetworkPolicy, error) {
+	return AddonNetworkPolicy{
+		Name: "node-exporter",
+		Ingress: []AddonNetworkPolicyRule{
+			{
+				Ports: []AddonNetworkPolicyPort{{Protocol: "TCP", Port: 9100}},
+				Peers: []AddonNetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}},
+			},
+		},
+	}, nil
+}
+
+type clusterAutoscalerAddon struct{}
+
+func (*clusterAutoscalerAddon) Name() string                { return "cluster-autoscaler" }
+func (*clusterAutoscalerAddon) Category() AddonCategory     { return AddonCategoryOptional }
+func (*clusterAutoscalerAddon) Enabled(*shootpkg.Shoot) bool { return true }
+func (*clusterAutoscalerAddon) Images() map[string]string   { return map[string]string{} }
+func (*clusterAutoscalerAddon) GenerateConfig(b *HybridBotanist) (map[string]interface{}, error) {
+	return b.Botanist.GenerateClusterAutoscalerConfig()
+}
+
+type helmTillerAddon struct{}
+
+func (*helmTillerAddon) Name() string                { return "helm-tiller" }
+func (*helmTillerAddon) Category() AddonCategory     { return AddonCategoryOptional }
+func (*helmTillerAddon) Enabled(*shootpkg.Shoot) bool { return true }
+func (*helmTillerAddon) Images() map[string]string {
+	return map[string]string{"helm-tiller": "helm-tiller"}
+}
+func (*helmTillerAddon) GenerateConfig(b *HybridBotanist) (map[string]interface{}, error) {
+	return b.Botanist.GenerateHelmTillerConfig()
+}
+
+type kubeLegoAddon struct{}
+
+func (*kubeLegoAddon) Name() string                { return "kube-lego" }
+func (*kubeLegoAddon) Category() AddonCategory     { return AddonCategoryOptional }
+func (*kubeLegoAddon) Enabled(*shootpkg.Shoot) bool { return true }
+func (*kubeLegoAddon) Images() map[string]string   { return map[string]string{"kube-lego": "kube-lego"} }
+func (*kubeLegoAddon) GenerateConfig(b *HybridBotanist) (map[string]interface{}, error) {
+	return b.Botanist.GenerateKubeLegoConfig()
+}
+
+type kube2IAMAddon struct{}
+
+func (*kube2IAMAddon) Name() string                { return "kube2iam" }
+func (*kube2IAMAddon) Category() AddonCategory     { return AddonCategoryOptional }
+func (*kube2IAMAddon) Enabled(*shootpkg.Shoot) bool { return true }
+func (*kube2IAMAddon) Images() map[string]string   { return map[string]string{"kube2iam": "kube2iam"} }
+func (*kube2IAMAddon) GenerateConfig(b *HybridBotanist) (map[string]interface{}, error) {
+	return b.ShootCloudBotanist.GenerateKube2IAMConfig()
+}
+
+type kubernetesDashboardAddon struct{}
+
+func (*kubernetesDashboardAddon) Name() string                { return "kubernetes-dashboard" }
+func (*kubernetesDashboardAddon) Category() AddonCategory     { return AddonCategoryOptional }
+func (*kubernetesDashboardAddon) Enabled(*shootpkg.Shoot) bool { return true }
+func (*kubernetesDashboardAddon) Images() map[string]string {
+	return map[string]string{"kubernetes-dashboard": "kubernetes-dashboard"}
+}
+func (*kubernetesDashboardAddon) GenerateConfig(b *HybridBotanist) (map[string]interface{}, error) {
+	return b.Botanist.GenerateKubernetesDashboardConfig()
+}
+
+type monocularAddon struct{}
+
+func (*monocularAddon) Name() string                { return "monocular" }
+func (*monocularAddon) Category() AddonCategory     { return AddonCategoryOptional }
+func (*monocularAddon) Enabled(*shootpkg.Shoot) bool { return true }
+func (*monocularAddon) Images() map[string]string {
+	return map[string]string{"monocular-api": "monocular-api", "monocular-ui": "monocular-ui", "busybox": "busybox"}
+}
+func (*monocularAddon) GenerateConfig(b *HybridBotanist) (map[string]interface{}, error) {
+	return b.Botanist.GenerateMonocularConfig()
+}
+
+type nginxIngressAddon struct{}
+
+func (*nginxIngressAddon) Name() string                { return "nginx-ingress" }
+func (*nginxIngressAddon) Category() AddonCategory     { return AddonCategoryOptional }
+func (*nginxIngressAddon) Enabled(*shootpkg.Shoot) bool { return true }
+func (*nginxIngressAddon) Images() map[string]string {
+	return map[string]string{"nginx-ingress-controller": "nginx-ingress-controller", "ingress-default-backend": "ingress-default-backend"}
+}
+func (*nginxIngressAddon) GenerateConfig(b *HybridBotanist) (map[string]interface{}, error) {
+	config, err := b.ShootCloudBotanist.GenerateNginxIngressConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if b.Shoot.NginxIngressEnabled() {
+		config = utils.MergeMaps(config, map[string]interface{}{
+			"controller": map[string]interface{}{
+				"service": map[string]interface{}{
+					"loadBalancerSourceRanges": b.Shoot.Info.Spec.Addons.NginxIngress.LoadBalancerSourceRanges,
+				},
+			},
+		})
+
+		if shootUsedAsSeed, _, _ := helper.IsUsedAsSeed(b.Shoot.Info); shootUsedAsSeed {
+			config = utils.MergeMaps(config, map[string]interface{}{
+				"controller": map[string]interface{}{
+					"resources": map[string]interface{}{
+						"limits": map[string]interface{}{
+							"cpu":    "500m",
+							"memory": "1024Mi",
+						},
+					},
+				},
+			})
+		}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,318 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybridbotanist
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	"github.com/gardener/gardener/pkg/chartrenderer"
+	shootpkg "github.com/gardener/gardener/pkg/operation/shoot"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// addonCategories lists every AddonCategory that persistAddonInventory stores a ConfigMap entry for, in the
+// order loadAddonInventory reads them back in.
+var addonCategories = []AddonCategory{AddonCategoryCore, AddonCategoryOptional, AddonCategoryAdmissionControl}
+
+// addonInventoryConfigMapName is the ConfigMap that gardener-addon-inventory stores its per-category
+// addon-to-resource mapping in, inside the shoot's seed namespace. gardener-controller-manager reconciles
+// it against the live shoot state to populate shoot.status.addonConditions and to answer
+// /healthz/addons/<name>.
+const addonInventoryConfigMapName = "gardener-addon-inventory"
+
+// AddonResourceRef identifies a single Kubernetes resource that a chart rendered on behalf of an addon.
+type AddonResourceRef struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// AddonInventory maps an addon name to the resources its chart produced.
+type AddonInventory map[string][]AddonResourceRef
+
+// buildAddonInventory walks the manifests of a rendered addon chart and groups the contained
+// Deployments, DaemonSets and Services by the addon (sub-chart) that produced them.
+func buildAddonInventory(chart *chartrenderer.RenderedChart) (AddonInventory, error) {
+	inventory := AddonInventory{}
+
+	for _, manifest := range chart.Manifests() {
+		addonName := addonNameFromManifestPath(manifest.Name)
+		if addonName == "" {
+			continue
+		}
+
+		var obj struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		}
+		// Not every entry under a sub-chart's path is a plain Kubernetes manifest (e.g. NOTES.txt); skip
+		// anything that doesn't parse as one instead of failing the whole inventory.
+		if err := yaml.Unmarshal(manifest.Content, &obj); err != nil {
+			continue
+		}
+		if obj.Kind != "Deployment" && obj.Kind != "DaemonSet" && obj.Kind != "Service" {
+			continue
+		}
+
+		inventory[addonName] = append(inventory[addonName], AddonResourceRef{Kind: obj.Kind, Name: obj.Metadata.Name})
+	}
+
+	return inventory, nil
+}
+
+// addonNameFromManifestPath extracts the addon (sub-chart) name from a rendered manifest's path, e.g.
+// "shoot-core/charts/kube-dns/templates/deployment.yaml" yields "kube-dns".
+func addonNameFromManifestPath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if part == "charts" && i+1 < len(parts) {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+// persistAddonInventory renders the given category's addon inventory into the
+// gardener-addon-inventory ConfigMap of the shoot's seed namespace, one data key per category, so that
+// gardener-controller-manager's addon-health controller can reconcile it against the live shoot state.
+func (b *HybridBotanist) persistAddonInventory(category AddonCategory, inventory AddonInventory) error {
+	raw, err := yaml.Marshal(inventory)
+	if err != nil {
+		return fmt.Errorf("failed to marshal addon inventory for category %q: %v", category, err)
+	}
+
+	cm, err := b.K8sSeedClient.GetConfigMap(b.Shoot.SeedNamespace, addonInventoryConfigMapName)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      addonInventoryConfigMapName,
+				Namespace: b.Shoot.SeedNamespace,
+			},
+			Data: map[string]string{},
+		}
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[strings.ToLower(string(category))+".yaml"] = string(raw)
+
+	_, err = b.K8sSeedClient.CreateConfigMap(b.Shoot.SeedNamespace, addonInventoryConfigMapName, cm.Data, true)
+	return err
+}
+
+// recordAddonInventory extracts the addon inventory from a freshly rendered chart and persists it. It is
+// called once per generate*Chart method right after rendering, so that gardener-addon-inventory reflects
+// the manifests of the latest render. Failures are logged rather than propagated: the inventory backs health
+// reporting only and must never block addon deployment. A failed write leaves the previous inventory in place.
+func (b *HybridBotanist) recordAddonInventory(category AddonCategory, chart *chartrenderer.RenderedChart) {
+	inventory, err := buildAddonInventory(chart)
+	if err != nil {
+		b.Logger.Errorf("could not build addon inventory for category %q: %v", category, err)
+		return
+	}
+	if err := b.persistAddonInventory(category, inventory); err != nil {
+		b.Logger.Errorf("could not persist addon inventory for category %q: %v", category, err)
+	}
+}
+
+// addonConditionsFromInventory derives one gardenv1beta1.AddonCondition per addon in the inventory,
+// reflecting whether all of the addon's Deployments/DaemonSets/Services are available in the shoot. It is
+// the building block for the addon-health controller that populates shoot.status.addonConditions and backs
+// the gardener-controller-manager /healthz/addons/<name> endpoint.
+//
+// LastTransitionTime is intentionally left unset: the caller reconciling shoot.status.addonConditions
+// knows the previously persisted phase and must only bump the timestamp when the phase actually changes.
+func (b *HybridBotanist) addonConditionsFromInventory(inventory AddonInventory) []gardenv1beta1.AddonCondition {
+	conditions := make([]gardenv1beta1.AddonCondition, 0, len(inventory))
+
+	for name, refs := range inventory {
+		condition := gardenv1beta1.AddonCondition{
+			Name:  name,
+			Phase: gardenv1beta1.AddonPhaseReady,
+		}
+
+		for _, ref := range refs {
+			ready, err := b.addonResourceReady(ref)
+			if err != nil {
+				condition.Phase = gardenv1beta1.AddonPhaseUnknown
+				condition.Message = err.Error()
+				break
+			}
+			if !ready {
+				condition.Phase = gardenv1beta1.AddonPhaseNotReady
+				condition.Message = fmt.Sprintf("%s %q is not ready", ref.Kind, ref.Name)
+				break
+			}
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	return conditions
+}
+
+// addonResourceReady reports whether a single resource tracked in the addon inventory is ready in the
+// shoot cluster.
+func (b *HybridBotanist) addonResourceReady(ref AddonResourceRef) (bool, error) {
+	switch ref.Kind {
+	case "Deployment":
+		deployment, err := b.K8sShootClient.GetDeployment(metav1.NamespaceSystem, ref.Name)
+		if err != nil {
+			return false, err
+		}
+		var desiredReplicas int32 = 1
+		if deployment.Spec.Replicas != nil {
+			desiredReplicas = *deployment.Spec.Replicas
+		}
+		return deployment.Status.ReadyReplicas == desiredReplicas, nil
+	case "DaemonSet":
+		daemonSet, err := b.K8sShootClient.GetDaemonSet(metav1.NamespaceSystem, ref.Name)
+		if err != nil {
+			return false, err
+		}
+		return daemonSet.Status.NumberReady == daemonSet.Status.DesiredNumberScheduled, nil
+	default:
+		// Services have no readiness concept of their own; their existence is enough.
+		return true, nil
+	}
+}
+
+// loadAddonInventory reads the gardener-addon-inventory ConfigMap written by persistAddonInventory back and
+// merges every category's entries into a single AddonInventory. It returns an empty inventory, not an
+// error, if the ConfigMap hasn't been written yet (e.g. the shoot's first reconcile hasn't run).
+func (b *HybridBotanist) loadAddonInventory() (AddonInventory, error) {
+	cm, err := b.K8sSeedClient.GetConfigMap(b.Shoot.SeedNamespace, addonInventoryConfigMapName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return AddonInventory{}, nil
+		}
+		return nil, err
+	}
+
+	merged := AddonInventory{}
+	for _, category := range addonCategories {
+		raw, ok := cm.Data[strings.ToLower(string(category))+".yaml"]
+		if !ok {
+			continue
+		}
+
+		var inventory AddonInventory
+		if err := yaml.Unmarshal([]byte(raw), &inventory); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal addon inventory for category %q: %v", category, err)
+		}
+		for name, refs := range inventory {
+			merged[name] = refs
+		}
+	}
+
+	return merged, nil
+}
+
+// ReconcileAddonHealth recomputes shoot.status.addonConditions from the addon inventory persisted by
+// recordAddonInventory and updates the shoot. Unlike recordAddonInventory, which only runs synchronously
+// while a chart is being rendered, this is meant to be invoked periodically by RunAddonHealthController so
+// that an addon degrading or recovering between shoot reconciliations is still reflected in its status.
+func (b *HybridBotanist) ReconcileAddonHealth(ctx context.Context) error {
+	inventory, err := b.loadAddonInventory()
+	if err != nil {
+		return fmt.Errorf("failed to load addon inventory: %v", err)
+	}
+
+	conditions := b.addonConditionsFromInventory(inventory)
+	mergeAddonConditionTransitionTimes(conditions, b.Shoot.Info.Status.AddonConditions)
+
+	b.Shoot.Info.Status.AddonConditions = conditions
+	return b.K8sGardenClient.UpdateShootStatus(ctx, b.Shoot.Info)
+}
+
+// mergeAddonConditionTransitionTimes stamps each of the newly computed conditions with
+// metav1.Now() unless a previous condition of the same name already had the same phase, in which case its
+// LastTransitionTime carries over unchanged. conditions is modified in place.
+func mergeAddonConditionTransitionTimes(conditions, previous []gardenv1beta1.AddonCondition) {
+	previousByName := make(map[string]gardenv1beta1.AddonCondition, len(previous))
+	for _, condition := range previous {
+		previousByName[condition.Name] = condition
+	}
+
+	for i, condition := range conditions {
+		if prev, ok := previousByName[condition.Name]; ok && prev.Phase == condition.Phase {
+			conditions[i].LastTransitionTime = prev.LastTransitionTime
+			continue
+		}
+		conditions[i].LastTransitionTime = metav1.Now()
+	}
+}
+
+// RunAddonHealthController periodically calls ReconcileAddonHealth for every shoot currently known to
+// gardener-controller-manager, until ctx is cancelled. It is started once, alongside the other shoot
+// controllers, by cmd/gardener-controller-manager/app.AddAddonHealth.
+func RunAddonHealthController(ctx context.Context, interval time.Duration, botanists func() []*HybridBotanist) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, b := range botanists() {
+				if err := b.ReconcileAddonHealth(ctx); err != nil {
+					b.Logger.Errorf("could not reconcile addon health for shoot %q: %v", b.Shoot.Info.Name, err)
+				}
+			}
+		}
+	}
+}
+
+// AddonHealthHandler serves gardener-controller-manager's "/healthz/addons/<name>" endpoint (registered by
+// cmd/gardener-controller-manager/app.AddAddonHealth): it reports whether the named addon's condition, as
+// last computed by ReconcileAddonHealth, is ready.
+type AddonHealthHandler struct {
+	Shoot *shootpkg.Shoot
+}
+
+func (h *AddonHealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/healthz/addons/")
+	if name == "" || strings.Contains(name, "/") {
+		http.Error(w, "addon name missing from request path", http.StatusBadRequest)
+		return
+	}
+
+	for _, condition := range h.Shoot.Info.Status.AddonConditions {
+		if condition.Name != name {
+			continue
+		}
+		if condition.Phase != gardenv1beta1.AddonPhaseReady {
+			http.Error(w, condition.Message, http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	http.NotFound(w, r)
+}
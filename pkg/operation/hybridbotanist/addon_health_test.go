@@ -0,0 +1,56 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybridbotanist
+
+import (
+	"testing"
+	"time"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMergeAddonConditionTransitionTimesKeepsTimeOnUnchangedPhase(t *testing.T) {
+	previousTransition := metav1.NewTime(metav1.Now().Add(-time.Hour))
+	previous := []gardenv1beta1.AddonCondition{
+		{Name: "kube-dns", Phase: gardenv1beta1.AddonPhaseReady, LastTransitionTime: previousTransition},
+		{Name: "metrics-server", Phase: gardenv1beta1.AddonPhaseReady, LastTransitionTime: previousTransition},
+	}
+	conditions := []gardenv1beta1.AddonCondition{
+		{Name: "kube-dns", Phase: gardenv1beta1.AddonPhaseReady},
+		{Name: "metrics-server", Phase: gardenv1beta1.AddonPhaseNotReady},
+	}
+
+	mergeAddonConditionTransitionTimes(conditions, previous)
+
+	if !conditions[0].LastTransitionTime.Equal(&previousTransition) {
+		t.Errorf("expected kube-dns's LastTransitionTime to carry over unchanged, got %v", conditions[0].LastTransitionTime)
+	}
+	if conditions[1].LastTransitionTime.Equal(&previousTransition) {
+		t.Errorf("expected metrics-server's LastTransitionTime to be bumped on phase change")
+	}
+}
+
+func TestMergeAddonConditionTransitionTimesStampsNewConditions(t *testing.T) {
+	conditions := []gardenv1beta1.AddonCondition{
+		{Name: "kube-dns", Phase: gardenv1beta1.AddonPhaseReady},
+	}
+
+	mergeAddonConditionTransitionTimes(conditions, nil)
+
+	if conditions[0].LastTransitionTime.IsZero() {
+		t.Error("expected a first-seen condition to get a non-zero LastTransitionTime")
+	}
+}
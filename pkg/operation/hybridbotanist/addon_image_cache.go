@@ -0,0 +1,104 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybridbotanist
+
+import (
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	shootpkg "github.com/gardener/gardener/pkg/operation/shoot"
+)
+
+// imageCacheNodePort is the port the pull-through registry cache listens on on every node, bound to the
+// node's loopback interface only.
+const imageCacheNodePort = 5000
+
+func init() {
+	Register(&imageCacheAddon{})
+	Register(&imageCacheAdmissionAddon{})
+}
+
+// imageCacheEnabled reports whether the shoot has the image-cache addon turned on.
+func imageCacheEnabled(shoot *shootpkg.Shoot) bool {
+	addons := shoot.Info.Spec.Addons
+	return addons != nil && addons.ImageCache != nil && addons.ImageCache.Enabled
+}
+
+// imageCacheAddon deploys a hostPath-backed registry:2 DaemonSet into kube-system that every node's
+// kubelet (via the rewritten image refs produced by imageCacheAdmissionAddon) pulls through instead of
+// reaching out to the upstream registry directly.
+type imageCacheAddon struct{}
+
+func (*imageCacheAddon) Name() string                       { return "image-cache" }
+func (*imageCacheAddon) Category() AddonCategory            { return AddonCategoryCore }
+func (*imageCacheAddon) Enabled(shoot *shootpkg.Shoot) bool { return imageCacheEnabled(shoot) }
+func (*imageCacheAddon) Images() map[string]string {
+	return map[string]string{"image-cache": "registry"}
+}
+func (*imageCacheAddon) GenerateConfig(b *HybridBotanist) (map[string]interface{}, error) {
+	return buildImageCacheConfig(b.Shoot.Info.Spec.Addons.ImageCache), nil
+}
+
+// buildImageCacheConfig renders the image-cache addon's chart values from its API configuration.
+func buildImageCacheConfig(imageCache *gardenv1beta1.ImageCache) map[string]interface{} {
+	config := map[string]interface{}{
+		"port":         imageCacheNodePort,
+		"cacheSizeGiB": imageCache.CacheSizeGiB,
+		"hostPath":     "/var/lib/image-cache",
+		"gc": map[string]interface{}{
+			"enabled": true,
+		},
+	}
+
+	if imageCache.UpstreamCredentialsSecretRef != nil {
+		config["upstreamCredentialsSecretRef"] = imageCache.UpstreamCredentialsSecretRef.Name
+	}
+
+	return config
+}
+
+// NetworkPolicy allows the image-cache DaemonSet egress to the upstream registries it pulls images from on
+// behalf of every node. Unlike every other Core addon's single, known destination, the image cache's
+// destinations are arbitrary internet registries (Docker Hub, gcr.io, quay.io, ...) chosen by whatever image
+// a workload references, so this is the one legitimate case for a peer-unrestricted rule: it is still scoped
+// to HTTPS, not opened up entirely. Ingress is node-local only (the cache is bound to each node's loopback
+// interface), which NetworkPolicy does not govern, so no ingress rule is needed.
+func (*imageCacheAddon) NetworkPolicy(*HybridBotanist) (AddonNetworkPolicy, error) {
+	return AddonNetworkPolicy{
+		Name: "image-cache",
+		Egress: []AddonNetworkPolicyRule{
+			{
+				Ports: []AddonNetworkPolicyPort{{Protocol: "TCP", Port: 443}},
+			},
+		},
+	}, nil
+}
+
+// imageCacheAdmissionAddon installs the mutating webhook that rewrites image references on admitted Pods
+// to the node-local image-cache addon, unless a reference already points at it. The
+// MutatingWebhookConfiguration itself is rendered by the shoot-admission-controls chart, like every other
+// addon's Kubernetes manifests; this only produces its chart values.
+type imageCacheAdmissionAddon struct{}
+
+func (*imageCacheAdmissionAddon) Name() string                       { return "image-cache-webhook" }
+func (*imageCacheAdmissionAddon) Category() AddonCategory            { return AddonCategoryAdmissionControl }
+func (*imageCacheAdmissionAddon) Enabled(shoot *shootpkg.Shoot) bool { return imageCacheEnabled(shoot) }
+func (*imageCacheAdmissionAddon) Images() map[string]string {
+	return map[string]string{"image-cache-webhook": "image-cache-webhook"}
+}
+func (*imageCacheAdmissionAddon) GenerateConfig(b *HybridBotanist) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"rewriteTarget": "localhost",
+		"port":          imageCacheNodePort,
+	}, nil
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybridbotanist
+
+import (
+	"testing"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestBuildImageCacheConfig(t *testing.T) {
+	config := buildImageCacheConfig(&gardenv1beta1.ImageCache{Enabled: true, CacheSizeGiB: 20})
+
+	if config["port"] != imageCacheNodePort {
+		t.Errorf("config[port] = %v, want %d", config["port"], imageCacheNodePort)
+	}
+	if config["cacheSizeGiB"] != int64(20) {
+		t.Errorf("config[cacheSizeGiB] = %v, want 20", config["cacheSizeGiB"])
+	}
+	if _, ok := config["upstreamCredentialsSecretRef"]; ok {
+		t.Error("expected no upstreamCredentialsSecretRef to be set when ImageCache.UpstreamCredentialsSecretRef is nil")
+	}
+}
+
+func TestBuildImageCacheConfigWithUpstreamCredentials(t *testing.T) {
+	imageCache := &gardenv1beta1.ImageCache{
+		Enabled:                      true,
+		UpstreamCredentialsSecretRef: &corev1.LocalObjectReference{Name: "image-cache-upstream"},
+	}
+
+	config := buildImageCacheConfig(imageCache)
+
+	if config["upstreamCredentialsSecretRef"] != "image-cache-upstream" {
+		t.Errorf("config[upstreamCredentialsSecretRef] = %v, want %q", config["upstreamCredentialsSecretRef"], "image-cache-upstream")
+	}
+}
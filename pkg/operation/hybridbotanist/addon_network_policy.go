@@ -0,0 +1,88 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybridbotanist
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AddonNetworkPolicyPort is a single port/protocol pair allowed by an AddonNetworkPolicyRule.
+type AddonNetworkPolicyPort struct {
+	Protocol string
+	Port     int32
+}
+
+// AddonNetworkPolicyPeer mirrors networking.k8s.io/v1 NetworkPolicyPeer. Exactly one of the three fields
+// is expected to be set, matching upstream's semantics.
+type AddonNetworkPolicyPeer struct {
+	PodSelector       *metav1.LabelSelector
+	NamespaceSelector *metav1.LabelSelector
+	CIDR              string
+}
+
+// AddonNetworkPolicyRule is one ingress or egress rule of an AddonNetworkPolicy.
+type AddonNetworkPolicyRule struct {
+	Ports []AddonNetworkPolicyPort
+	Peers []AddonNetworkPolicyPeer
+}
+
+// AddonNetworkPolicy is the expected ingress/egress traffic of a single addon, contributed by the addon
+// itself so that generateCoreAddonsChart can render a NetworkPolicy per addon instead of leaving kube-system
+// unrestricted.
+type AddonNetworkPolicy struct {
+	Name    string
+	Ingress []AddonNetworkPolicyRule
+	Egress  []AddonNetworkPolicyRule
+}
+
+// AddonNetworkPolicyProvider is implemented by addons that want a NetworkPolicy rendered for them. It is
+// optional: addons running with hostNetwork (e.g. calico, kube-proxy) typically have nothing to gain from
+// one and can leave it unimplemented.
+type AddonNetworkPolicyProvider interface {
+	NetworkPolicy(b *HybridBotanist) (AddonNetworkPolicy, error)
+}
+
+// singleHostPeer scopes a rule to exactly one IP address, e.g. the apiserver cluster IP or a seed VPN
+// endpoint, instead of an entire network or a wildcard.
+func singleHostPeer(ip string) AddonNetworkPolicyPeer {
+	return AddonNetworkPolicyPeer{CIDR: ip + "/32"}
+}
+
+// generateAddonNetworkPolicies collects the AddonNetworkPolicy contributed by every enabled, registered
+// core addon that implements AddonNetworkPolicyProvider.
+func (b *HybridBotanist) generateAddonNetworkPolicies() ([]AddonNetworkPolicy, error) {
+	var policies []AddonNetworkPolicy
+
+	for _, plugin := range Registry() {
+		if plugin.Category() != AddonCategoryCore || !plugin.Enabled(b.Shoot) {
+			continue
+		}
+
+		provider, ok := plugin.(AddonNetworkPolicyProvider)
+		if !ok {
+			continue
+		}
+
+		policy, err := provider.NetworkPolicy(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate network policy for addon %q: %v", plugin.Name(), err)
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybridbotanist
+
+import "testing"
+
+func TestSingleHostPeerIsNeverAWildcard(t *testing.T) {
+	cases := []string{"100.64.0.1", "10.250.0.1", "8.8.8.8"}
+
+	for _, ip := range cases {
+		peer := singleHostPeer(ip)
+		if peer.CIDR == "0.0.0.0/0" {
+			t.Fatalf("singleHostPeer(%q) produced a wildcard CIDR", ip)
+		}
+		want := ip + "/32"
+		if peer.CIDR != want {
+			t.Errorf("singleHostPeer(%q).CIDR = %q, want %q", ip, peer.CIDR, want)
+		}
+	}
+}
+
+// TestEveryPodNetworkedCoreAddonHasANetworkPolicy guards the scenario the kubeSystemIsolation flag exists
+// for: once it is on, kube-system becomes default-deny and only an addon that contributes its own
+// AddonNetworkPolicy stays reachable. calicoAddon and kubeProxyAddon run with hostNetwork and are
+// deliberately exempt (see AddonNetworkPolicyProvider's doc comment); every other Core addon, including ones
+// added after this test, must implement AddonNetworkPolicyProvider or kubeSystemIsolation silently breaks it.
+func TestEveryPodNetworkedCoreAddonHasANetworkPolicy(t *testing.T) {
+	hostNetworkCoreAddons := map[string]bool{"calico": true, "kube-proxy": true}
+
+	for _, plugin := range Registry() {
+		if plugin.Category() != AddonCategoryCore || hostNetworkCoreAddons[plugin.Name()] {
+			continue
+		}
+		if _, ok := plugin.(AddonNetworkPolicyProvider); !ok {
+			t.Errorf("Core addon %q has no NetworkPolicy; enabling kubeSystemIsolation would default-deny its traffic", plugin.Name())
+		}
+	}
+}
+
+func TestImageCacheNetworkPolicyHasNoIngressAndEgressScopedToHTTPS(t *testing.T) {
+	policy, err := (&imageCacheAddon{}).NetworkPolicy(nil)
+	if err != nil {
+		t.Fatalf("NetworkPolicy returned an error: %v", err)
+	}
+
+	if len(policy.Ingress) != 0 {
+		t.Errorf("expected no ingress rule, got %+v", policy.Ingress)
+	}
+	if len(policy.Egress) != 1 {
+		t.Fatalf("expected exactly one egress rule, got %+v", policy.Egress)
+	}
+
+	rule := policy.Egress[0]
+	if len(rule.Ports) != 1 || rule.Ports[0].Protocol != "TCP" || rule.Ports[0].Port != 443 {
+		t.Errorf("expected the egress rule to be scoped to TCP/443, got %+v", rule.Ports)
+	}
+	if len(rule.Peers) != 0 {
+		t.Errorf("expected the egress rule to have no peer restriction (upstream registries are arbitrary internet hosts), got %+v", rule.Peers)
+	}
+}
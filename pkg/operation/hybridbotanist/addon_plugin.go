@@ -0,0 +1,111 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybridbotanist
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	shootpkg "github.com/gardener/gardener/pkg/operation/shoot"
+)
+
+// AddonCategory classifies an AddonPlugin by which kube-addon-manager chart it is rendered into.
+type AddonCategory string
+
+const (
+	// AddonCategoryCore marks an addon as part of the shoot-core chart.
+	AddonCategoryCore AddonCategory = "Core"
+	// AddonCategoryOptional marks an addon as part of the shoot-addons chart.
+	AddonCategoryOptional AddonCategory = "Optional"
+	// AddonCategoryAdmissionControl marks an addon as part of the shoot-admission-controls chart.
+	AddonCategoryAdmissionControl AddonCategory = "AdmissionControl"
+)
+
+// AddonPlugin is implemented by every addon that can be rendered by the HybridBotanist into one of the
+// kube-addon-manager charts. Built-in addons register themselves via Register in an init() function;
+// out-of-tree addons (compiled in or loaded as Go plugins) can do the same to extend the set of addons
+// without having to patch generateCoreAddonsChart, generateOptionalAddonsChart or
+// generateAdmissionControlsChart.
+type AddonPlugin interface {
+	// Name returns the addon's chart values key, e.g. "kube-dns".
+	Name() string
+	// Category returns the chart the addon is rendered into.
+	Category() AddonCategory
+	// Enabled determines whether the addon should be rendered for the given shoot.
+	Enabled(*shootpkg.Shoot) bool
+	// GenerateConfig computes the chart values for the addon. Image references are not expected to be
+	// resolved yet; the registry takes care of that using the map returned by Images.
+	GenerateConfig(*HybridBotanist) (map[string]interface{}, error)
+	// Images returns the chart-image-name-to-image-vector-name mapping that must be injected into the
+	// values returned by GenerateConfig.
+	Images() map[string]string
+}
+
+var addonPlugins sync.Map
+
+// Register adds an AddonPlugin to the registry. It is meant to be called from init() functions, either
+// for the built-in addons or for out-of-tree addons compiled into gardener-controller-manager.
+func Register(plugin AddonPlugin) {
+	addonPlugins.Store(plugin.Name(), plugin)
+}
+
+// Registry returns all addons that have been registered so far, sorted by name. sync.Map.Range has no
+// defined order, and callers (e.g. generateAddonsConfig, generateAddonNetworkPolicies) render the result
+// into chart values that end up in a Secret/ConfigMap content hash, so an unsorted iteration order would
+// make those churn on every reconcile even when nothing actually changed.
+func Registry() []AddonPlugin {
+	var plugins []AddonPlugin
+	addonPlugins.Range(func(_, value interface{}) bool {
+		plugins = append(plugins, value.(AddonPlugin))
+		return true
+	})
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name() < plugins[j].Name()
+	})
+	return plugins
+}
+
+// generateAddonsConfig renders the chart values for all registered, enabled addons of the given category,
+// keyed by addon name. Each addon's images are injected via Botanist.InjectImages before being merged into
+// the result.
+func (b *HybridBotanist) generateAddonsConfig(category AddonCategory) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+
+	for _, plugin := range Registry() {
+		if plugin.Category() != category || !plugin.Enabled(b.Shoot) {
+			continue
+		}
+
+		config, err := plugin.GenerateConfig(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate chart values for addon %q: %v", plugin.Name(), err)
+		}
+
+		injected, err := b.Botanist.InjectImages(config, b.K8sShootClient.Version(), plugin.Images())
+		if err != nil {
+			return nil, fmt.Errorf("failed to inject images for addon %q: %v", plugin.Name(), err)
+		}
+
+		rewritten, err := rewriteImageRepositories(injected, b.Shoot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewrite image repository for addon %q: %v", plugin.Name(), err)
+		}
+
+		values[plugin.Name()] = rewritten
+	}
+
+	return values, nil
+}
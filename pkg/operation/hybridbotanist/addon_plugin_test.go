@@ -0,0 +1,45 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybridbotanist
+
+import (
+	"testing"
+
+	shootpkg "github.com/gardener/gardener/pkg/operation/shoot"
+)
+
+type fakeAddonPlugin struct{ name string }
+
+func (f *fakeAddonPlugin) Name() string                { return f.name }
+func (f *fakeAddonPlugin) Category() AddonCategory     { return AddonCategoryOptional }
+func (f *fakeAddonPlugin) Enabled(*shootpkg.Shoot) bool { return true }
+func (f *fakeAddonPlugin) GenerateConfig(*HybridBotanist) (map[string]interface{}, error) {
+	return nil, nil
+}
+func (f *fakeAddonPlugin) Images() map[string]string { return nil }
+
+func TestRegistryIsSortedByName(t *testing.T) {
+	Register(&fakeAddonPlugin{name: "zzz-registry-sort-test"})
+	Register(&fakeAddonPlugin{name: "aaa-registry-sort-test"})
+	Register(&fakeAddonPlugin{name: "mmm-registry-sort-test"})
+
+	plugins := Registry()
+
+	for i := 1; i < len(plugins); i++ {
+		if plugins[i-1].Name() > plugins[i].Name() {
+			t.Fatalf("Registry() is not sorted by name: %q comes before %q", plugins[i-1].Name(), plugins[i].Name())
+		}
+	}
+}
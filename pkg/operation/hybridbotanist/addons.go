@@ -17,13 +17,9 @@ package hybridbotanist
 import (
 	"path/filepath"
 
-	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
-	"github.com/gardener/gardener/pkg/apis/garden/v1beta1/helper"
 	"github.com/gardener/gardener/pkg/chartrenderer"
 	"github.com/gardener/gardener/pkg/operation/common"
 	"github.com/gardener/gardener/pkg/utils"
-	"github.com/gardener/gardener/pkg/utils/secrets"
-	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -31,177 +27,52 @@ import (
 // generateCoreAddonsChart renders the kube-addon-manager configuration for the core addons. It will be
 // stored as a Secret (as it may contain credentials) and mounted into the Pod. The configuration contains
 // specially labelled Kubernetes manifests which will be created and periodically reconciled.
+//
+// The rendered values are assembled from every AddonPlugin registered under AddonCategoryCore; see
+// addon_plugin.go and addon_builtin.go.
 func (b *HybridBotanist) generateCoreAddonsChart() (*chartrenderer.RenderedChart, error) {
-	var (
-		kubeProxySecret  = b.Secrets["kube-proxy"]
-		vpnShootSecret   = b.Secrets["vpn-shoot"]
-		vpnTLSAuthSecret = b.Secrets["vpn-seed-tlsauth"]
-		global           = map[string]interface{}{
-			"podNetwork": b.Shoot.GetPodNetwork(),
-		}
-		calicoConfig = map[string]interface{}{
-			"cloudProvider": b.Shoot.CloudProvider,
-		}
-
-		kubeDNSConfig = map[string]interface{}{
-			"clusterDNS": common.ComputeClusterIP(b.Shoot.GetServiceNetwork(), 10),
-			// TODO: resolve conformance test issue before changing:
-			// https://github.com/kubernetes/kubernetes/blob/master/test/e2e/network/dns.go#L44
-			"domain": gardenv1beta1.DefaultDomain,
-		}
-		kubeProxyConfig = map[string]interface{}{
-			"kubeconfig": kubeProxySecret.Data["kubeconfig"],
-			"podAnnotations": map[string]interface{}{
-				"checksum/secret-kube-proxy": b.CheckSums["kube-proxy"],
-			},
-		}
-		metricsServerConfig = map[string]interface{}{
-			"tls": map[string]interface{}{
-				"caBundle": b.Secrets["ca-metrics-server"].Data[secrets.DataKeyCertificateCA],
-			},
-			"secret": map[string]interface{}{
-				"data": b.Secrets["metrics-server"].Data,
-			},
-		}
-		vpnShootConfig = map[string]interface{}{
-			"podNetwork":     b.Shoot.GetPodNetwork(),
-			"serviceNetwork": b.Shoot.GetServiceNetwork(),
-			"nodeNetwork":    b.Shoot.GetNodeNetwork(),
-			"tlsAuth":        vpnTLSAuthSecret.Data["vpn.tlsauth"],
-			"podAnnotations": map[string]interface{}{
-				"checksum/secret-vpn-shoot": b.CheckSums["vpn-shoot"],
-			},
-		}
-		nodeExporterConfig = map[string]interface{}{}
-	)
-
-	proxyConfig := b.Shoot.Info.Spec.Kubernetes.KubeProxy
-	if proxyConfig != nil {
-		kubeProxyConfig["featureGates"] = proxyConfig.FeatureGates
-	}
-
-	if openvpnDiffieHellmanSecret, ok := b.Secrets[common.GardenRoleOpenVPNDiffieHellman]; ok {
-		vpnShootConfig["diffieHellmanKey"] = openvpnDiffieHellmanSecret.Data["dh2048.pem"]
-	}
-
-	calico, err := b.Botanist.InjectImages(calicoConfig, b.K8sShootClient.Version(), map[string]string{"calico-node": "calico-node", "calico-cni": "calico-cni", "calico-typha": "calico-typha"})
-	if err != nil {
-		return nil, err
-	}
-	kubeDNS, err := b.Botanist.InjectImages(kubeDNSConfig, b.K8sShootClient.Version(), map[string]string{"kube-dns": "kube-dns", "kube-dns-dnsmasq": "kube-dns-dnsmasq", "kube-dns-sidecar": "kube-dns-sidecar", "kube-dns-autoscaler": "cluster-proportional-autoscaler"})
+	values, err := b.generateAddonsConfig(AddonCategoryCore)
 	if err != nil {
 		return nil, err
 	}
-	kubeProxy, err := b.Botanist.InjectImages(kubeProxyConfig, b.K8sShootClient.Version(), map[string]string{"hyperkube": "hyperkube"})
-	if err != nil {
-		return nil, err
-	}
-	metricsServer, err := b.Botanist.InjectImages(metricsServerConfig, b.K8sShootClient.Version(), map[string]string{"metrics-server": "metrics-server"})
-	if err != nil {
-		return nil, err
+
+	values["global"] = map[string]interface{}{
+		"podNetwork": b.Shoot.GetPodNetwork(),
 	}
-	vpnShoot, err := b.Botanist.InjectImages(vpnShootConfig, b.K8sShootClient.Version(), map[string]string{"vpn-shoot": "vpn-shoot"})
-	if err != nil {
-		return nil, err
+
+	// The shoot-core chart expects node-exporter's values nested under "monitoring.node-exporter"; the
+	// nodeExporterAddon plugin itself is keyed by its own name like every other addon.
+	if nodeExporter, ok := values["node-exporter"]; ok {
+		delete(values, "node-exporter")
+		values["monitoring"] = map[string]interface{}{
+			"node-exporter": nodeExporter,
+		}
 	}
-	nodeExporter, err := b.Botanist.InjectImages(nodeExporterConfig, b.K8sShootClient.Version(), map[string]string{"node-exporter": "node-exporter"})
+
+	networkPolicies, err := b.generateAddonNetworkPolicies()
 	if err != nil {
 		return nil, err
 	}
+	values["networkPolicies"] = networkPolicies
+	values["kubeSystemIsolation"] = b.Shoot.Info.Spec.Kubernetes.KubeSystemIsolation
 
-	if _, err := b.K8sShootClient.CreateSecret(metav1.NamespaceSystem, "vpn-shoot", corev1.SecretTypeOpaque, vpnShootSecret.Data, true); err != nil {
+	chart, err := b.ChartShootRenderer.Render(filepath.Join(common.ChartPath, "shoot-core"), "shoot-core", metav1.NamespaceSystem, values)
+	if err != nil {
 		return nil, err
 	}
+	b.recordAddonInventory(AddonCategoryCore, chart)
 
-	return b.ChartShootRenderer.Render(filepath.Join(common.ChartPath, "shoot-core"), "shoot-core", metav1.NamespaceSystem, map[string]interface{}{
-		"global":         global,
-		"kube-dns":       kubeDNS,
-		"kube-proxy":     kubeProxy,
-		"vpn-shoot":      vpnShoot,
-		"calico":         calico,
-		"metrics-server": metricsServer,
-		"monitoring": map[string]interface{}{
-			"node-exporter": nodeExporter,
-		},
-	})
+	return chart, nil
 }
 
 // generateOptionalAddonsChart renders the kube-addon-manager chart for the optional addons. It
 // will be stored as a Secret (as it may contain credentials) and mounted into the Pod. The configuration
 // contains specially labelled Kubernetes manifests which will be created and periodically reconciled.
+//
+// The rendered values are assembled from every AddonPlugin registered under AddonCategoryOptional; see
+// addon_plugin.go and addon_builtin.go.
 func (b *HybridBotanist) generateOptionalAddonsChart() (*chartrenderer.RenderedChart, error) {
-	clusterAutoscalerConfig, err := b.Botanist.GenerateClusterAutoscalerConfig()
-	if err != nil {
-		return nil, err
-	}
-	helmTillerConfig, err := b.Botanist.GenerateHelmTillerConfig()
-	if err != nil {
-		return nil, err
-	}
-	kubeLegoConfig, err := b.Botanist.GenerateKubeLegoConfig()
-	if err != nil {
-		return nil, err
-	}
-	kube2IAMConfig, err := b.ShootCloudBotanist.GenerateKube2IAMConfig()
-	if err != nil {
-		return nil, err
-	}
-	kubernetesDashboardConfig, err := b.Botanist.GenerateKubernetesDashboardConfig()
-	if err != nil {
-		return nil, err
-	}
-	monocularConfig, err := b.Botanist.GenerateMonocularConfig()
-	if err != nil {
-		return nil, err
-	}
-	nginxIngressConfig, err := b.ShootCloudBotanist.GenerateNginxIngressConfig()
-	if err != nil {
-		return nil, err
-	}
-	if b.Shoot.NginxIngressEnabled() {
-		nginxIngressConfig = utils.MergeMaps(nginxIngressConfig, map[string]interface{}{
-			"controller": map[string]interface{}{
-				"service": map[string]interface{}{
-					"loadBalancerSourceRanges": b.Shoot.Info.Spec.Addons.NginxIngress.LoadBalancerSourceRanges,
-				},
-			},
-		})
-
-		if shootUsedAsSeed, _, _ := helper.IsUsedAsSeed(b.Shoot.Info); shootUsedAsSeed {
-			nginxIngressConfig = utils.MergeMaps(nginxIngressConfig, map[string]interface{}{
-				"controller": map[string]interface{}{
-					"resources": map[string]interface{}{
-						"limits": map[string]interface{}{
-							"cpu":    "500m",
-							"memory": "1024Mi",
-						},
-					},
-				},
-			})
-		}
-	}
-
-	helmTiller, err := b.Botanist.InjectImages(helmTillerConfig, b.K8sShootClient.Version(), map[string]string{"helm-tiller": "helm-tiller"})
-	if err != nil {
-		return nil, err
-	}
-	kubeLego, err := b.Botanist.InjectImages(kubeLegoConfig, b.K8sShootClient.Version(), map[string]string{"kube-lego": "kube-lego"})
-	if err != nil {
-		return nil, err
-	}
-	kube2IAM, err := b.Botanist.InjectImages(kube2IAMConfig, b.K8sShootClient.Version(), map[string]string{"kube2iam": "kube2iam"})
-	if err != nil {
-		return nil, err
-	}
-	kubernetesDashboard, err := b.Botanist.InjectImages(kubernetesDashboardConfig, b.K8sShootClient.Version(), map[string]string{"kubernetes-dashboard": "kubernetes-dashboard"})
-	if err != nil {
-		return nil, err
-	}
-	monocular, err := b.Botanist.InjectImages(monocularConfig, b.K8sShootClient.Version(), map[string]string{"monocular-api": "monocular-api", "monocular-ui": "monocular-ui", "busybox": "busybox"})
-	if err != nil {
-		return nil, err
-	}
-	nginxIngress, err := b.Botanist.InjectImages(nginxIngressConfig, b.K8sShootClient.Version(), map[string]string{"nginx-ingress-controller": "nginx-ingress-controller", "ingress-default-backend": "ingress-default-backend"})
+	values, err := b.generateAddonsConfig(AddonCategoryOptional)
 	if err != nil {
 		return nil, err
 	}
@@ -224,15 +95,13 @@ func (b *HybridBotanist) generateOptionalAddonsChart() (*chartrenderer.RenderedC
 		}
 	}
 
-	return b.ChartShootRenderer.Render(filepath.Join(common.ChartPath, "shoot-addons"), "addons", metav1.NamespaceSystem, map[string]interface{}{
-		"cluster-autoscaler":   clusterAutoscalerConfig,
-		"helm-tiller":          helmTiller,
-		"kube-lego":            kubeLego,
-		"kube2iam":             kube2IAM,
-		"kubernetes-dashboard": kubernetesDashboard,
-		"monocular":            monocular,
-		"nginx-ingress":        nginxIngress,
-	})
+	chart, err := b.ChartShootRenderer.Render(filepath.Join(common.ChartPath, "shoot-addons"), "addons", metav1.NamespaceSystem, values)
+	if err != nil {
+		return nil, err
+	}
+	b.recordAddonInventory(AddonCategoryOptional, chart)
+
+	return chart, nil
 }
 
 // generateAdmissionControlsChart renders the kube-addon-manager configuration for the admission control
@@ -244,5 +113,17 @@ func (b *HybridBotanist) generateAdmissionControlsChart() (*chartrenderer.Render
 		return nil, err
 	}
 
-	return b.ChartShootRenderer.Render(filepath.Join(common.ChartPath, "shoot-admission-controls"), "admission-controls", metav1.NamespaceSystem, config)
+	addons, err := b.generateAddonsConfig(AddonCategoryAdmissionControl)
+	if err != nil {
+		return nil, err
+	}
+	config = utils.MergeMaps(config, addons)
+
+	chart, err := b.ChartShootRenderer.Render(filepath.Join(common.ChartPath, "shoot-admission-controls"), "admission-controls", metav1.NamespaceSystem, config)
+	if err != nil {
+		return nil, err
+	}
+	b.recordAddonInventory(AddonCategoryAdmissionControl, chart)
+
+	return chart, nil
 }
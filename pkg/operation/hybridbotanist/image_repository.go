@@ -0,0 +1,94 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybridbotanist
+
+import (
+	"strings"
+
+	"github.com/gardener/gardener/pkg/apis/garden/v1beta1/validation"
+	shootpkg "github.com/gardener/gardener/pkg/operation/shoot"
+)
+
+// rewriteImageRepositories redirects the registry of every addon image resolved by InjectImages to the
+// repository configured on the shoot (spec.imageRepository), honouring per-image overrides
+// (spec.imageRepositoryOverrides) where given. It leaves config untouched if the shoot sets neither.
+//
+// spec.imageRepository and spec.imageRepositoryOverrides are already validated as well-formed registry
+// hostnames by the ShootImageRepositoryValidator admission plugin (plugin/pkg/shoot/imagerepository, see
+// validation.ValidateShootSpec) before they can be persisted; the check here is defense in depth against
+// shoots that were created before the plugin existed or clusters that don't enable it.
+func rewriteImageRepositories(config map[string]interface{}, shoot *shootpkg.Shoot) (map[string]interface{}, error) {
+	repository := shoot.Info.Spec.ImageRepository
+	overrides := shoot.Info.Spec.ImageRepositoryOverrides
+
+	if repository == "" && len(overrides) == 0 {
+		return config, nil
+	}
+
+	if repository != "" {
+		if err := validation.ValidateImageRepositoryHostname(repository); err != nil {
+			return nil, err
+		}
+	}
+	for _, override := range overrides {
+		if err := validation.ValidateImageRepositoryHostname(override); err != nil {
+			return nil, err
+		}
+	}
+
+	images, ok := config["images"].(map[string]interface{})
+	if !ok {
+		return config, nil
+	}
+
+	for name, raw := range images {
+		image, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		currentRepository, ok := image["repository"].(string)
+		if !ok {
+			continue
+		}
+
+		if override, ok := overrides[name]; ok {
+			image["repository"] = rewriteImageRepository(currentRepository, override)
+			continue
+		}
+		if repository != "" {
+			image["repository"] = rewriteImageRepository(currentRepository, repository)
+		}
+	}
+
+	return config, nil
+}
+
+// rewriteImageRepository replaces only the registry host of an image reference with the given mirror,
+// preserving any repository path below it (e.g. "eu.gcr.io/gardener-project/gardener/calico-node:v1.2.3"
+// rewritten to "mirror.example.com" becomes "mirror.example.com/gardener-project/gardener/calico-node:v1.2.3").
+// An image reference with no registry host of its own (e.g. "busybox:1.29.2") is simply prefixed.
+func rewriteImageRepository(image, repository string) string {
+	segments := strings.Split(image, "/")
+	if len(segments) > 1 && looksLikeRegistryHost(segments[0]) {
+		segments = segments[1:]
+	}
+	return repository + "/" + strings.Join(segments, "/")
+}
+
+// looksLikeRegistryHost mirrors the heuristic Docker itself uses to tell a registry host apart from the
+// first path segment of an image name: it is a host if it contains a "." or ":", or is "localhost".
+func looksLikeRegistryHost(segment string) bool {
+	return segment == "localhost" || strings.ContainsAny(segment, ".:")
+}
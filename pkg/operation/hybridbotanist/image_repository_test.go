@@ -0,0 +1,53 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hybridbotanist
+
+import "testing"
+
+func TestRewriteImageRepositoryPreservesRepositoryPath(t *testing.T) {
+	cases := []struct {
+		image      string
+		repository string
+		want       string
+	}{
+		{
+			image:      "eu.gcr.io/gardener-project/gardener/calico-node:v1.2.3",
+			repository: "registry.example.com/mirror",
+			want:       "registry.example.com/mirror/gardener-project/gardener/calico-node:v1.2.3",
+		},
+		{
+			image:      "k8s.gcr.io/kube-dns:1.14.13",
+			repository: "registry.example.com/mirror",
+			want:       "registry.example.com/mirror/kube-dns:1.14.13",
+		},
+		{
+			image:      "quay.io/coreos/kube-lego:0.1.5",
+			repository: "harbor.example.com",
+			want:       "harbor.example.com/coreos/kube-lego:0.1.5",
+		},
+		{
+			// No registry host segment of its own - the whole reference is a repository path.
+			image:      "busybox:1.29.2",
+			repository: "registry.example.com/mirror",
+			want:       "registry.example.com/mirror/busybox:1.29.2",
+		},
+	}
+
+	for _, c := range cases {
+		if got := rewriteImageRepository(c.image, c.repository); got != c.want {
+			t.Errorf("rewriteImageRepository(%q, %q) = %q, want %q", c.image, c.repository, got, c.want)
+		}
+	}
+}
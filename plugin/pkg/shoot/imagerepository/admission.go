@@ -0,0 +1,68 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package imagerepository installs the ShootImageRepositoryValidator admission plugin into the Garden
+// apiserver, which rejects a Shoot at create/update time if spec.imageRepository or
+// spec.imageRepositoryOverrides is not a well-formed registry hostname. This is the API boundary
+// validation.ValidateShootSpec is meant to guard; pkg/operation/hybridbotanist's own call into the same
+// validation package is defense in depth for shoots that predate this plugin, not the primary check.
+package imagerepository
+
+import (
+	"context"
+	"io"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	"github.com/gardener/gardener/pkg/apis/garden/v1beta1/validation"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/admission"
+)
+
+// PluginName is the name under which this plugin is registered with the Garden apiserver.
+const PluginName = "ShootImageRepositoryValidator"
+
+// Register registers this plugin.
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(io.Reader) (admission.Interface, error) {
+		return New(), nil
+	})
+}
+
+// admissionHandler validates the imageRepository fields of Shoots being created or updated.
+type admissionHandler struct {
+	*admission.Handler
+}
+
+// New creates a new ShootImageRepositoryValidator admission plugin.
+func New() admission.Interface {
+	return &admissionHandler{Handler: admission.NewHandler(admission.Create, admission.Update)}
+}
+
+// Validate rejects the request if the Shoot's spec.imageRepository or spec.imageRepositoryOverrides is not a
+// well-formed registry hostname. It ignores any object that isn't a Shoot.
+func (h *admissionHandler) Validate(_ context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	shoot, ok := a.GetObject().(*gardenv1beta1.Shoot)
+	if !ok {
+		return nil
+	}
+
+	if errs := validation.ValidateShootSpec(&shoot.Spec, field.NewPath("spec")); len(errs) > 0 {
+		return apierrors.NewInvalid(a.GetKind().GroupKind(), shoot.Name, errs)
+	}
+
+	return nil
+}
+
+var _ admission.ValidationInterface = &admissionHandler{}
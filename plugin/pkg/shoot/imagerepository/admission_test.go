@@ -0,0 +1,63 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagerepository
+
+import (
+	"context"
+	"testing"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+)
+
+var shootKind = schema.GroupVersionKind{Group: "garden.sapcloud.io", Version: "v1beta1", Kind: "Shoot"}
+var shootResource = schema.GroupVersionResource{Group: "garden.sapcloud.io", Version: "v1beta1", Resource: "shoots"}
+
+func TestValidateRejectsMalformedImageRepository(t *testing.T) {
+	shoot := &gardenv1beta1.Shoot{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       gardenv1beta1.ShootSpec{ImageRepository: "not a hostname"},
+	}
+	attrs := admission.NewAttributesRecord(shoot, nil, shootKind, "", shoot.Name, shootResource, "", admission.Create, nil, false, nil)
+
+	if err := New().(admission.ValidationInterface).Validate(context.Background(), attrs, nil); err == nil {
+		t.Fatal("expected an error for a malformed spec.imageRepository, got nil")
+	}
+}
+
+func TestValidateAcceptsWellFormedImageRepository(t *testing.T) {
+	shoot := &gardenv1beta1.Shoot{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       gardenv1beta1.ShootSpec{ImageRepository: "mirror.example.com"},
+	}
+	attrs := admission.NewAttributesRecord(shoot, nil, shootKind, "", shoot.Name, shootResource, "", admission.Create, nil, false, nil)
+
+	if err := New().(admission.ValidationInterface).Validate(context.Background(), attrs, nil); err != nil {
+		t.Fatalf("expected no error for a well-formed spec.imageRepository, got %v", err)
+	}
+}
+
+func TestValidateIgnoresNonShootObjects(t *testing.T) {
+	configMapKind := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	configMapResource := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	attrs := admission.NewAttributesRecord(&corev1.ConfigMap{}, nil, configMapKind, "", "test", configMapResource, "", admission.Create, nil, false, nil)
+
+	if err := New().(admission.ValidationInterface).Validate(context.Background(), attrs, nil); err != nil {
+		t.Fatalf("expected non-Shoot objects to be ignored, got %v", err)
+	}
+}